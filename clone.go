@@ -0,0 +1,280 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// CloneOption configures the behavior of Clone.
+type CloneOption func(*cloneOptions)
+
+type cloneOptions struct {
+	allowUnexported bool
+}
+
+// AllowUnexported enables copying of unexported struct fields during
+// Clone, via unsafe. Without this option unexported fields are skipped.
+func AllowUnexported() CloneOption {
+	return func(o *cloneOptions) {
+		o.allowUnexported = true
+	}
+}
+
+// Clone returns a deep copy of src. Structs, slices, maps, pointers,
+// and arrays are recursed into; cycles are detected and preserved.
+// Unexported fields are skipped unless AllowUnexported is given.
+func Clone(src interface{}, opts ...CloneOption) interface{} {
+	o := &cloneOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	visited := make(map[uintptr]reflect.Value)
+	v := clone(reflect.ValueOf(src), o, visited)
+	return v.Interface()
+}
+
+func clone(src reflect.Value, o *cloneOptions, visited map[uintptr]reflect.Value) reflect.Value {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		addr := src.Pointer()
+		if v, ok := visited[addr]; ok && v.Type() == src.Type() {
+			return v
+		}
+		dst := reflect.New(src.Type().Elem())
+		visited[addr] = dst
+		dst.Elem().Set(clone(src.Elem(), o, visited))
+		return dst
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(clone(src.Elem(), o, visited))
+		return dst
+
+	case reflect.Struct:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.NumField(); i++ {
+			f := src.Type().Field(i)
+			if f.PkgPath != "" {
+				if o.allowUnexported {
+					cloneUnexportedField(dst.Field(i), clone(readUnexported(src, i), o, visited))
+				}
+				continue
+			}
+			dst.Field(i).Set(clone(src.Field(i), o, visited))
+		}
+		return dst
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		addr := src.Pointer()
+		if v, ok := visited[addr]; ok && v.Type() == src.Type() && v.Len() == src.Len() {
+			return v
+		}
+		dst := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		visited[addr] = dst
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(clone(src.Index(i), o, visited))
+		}
+		return dst
+
+	case reflect.Array:
+		dst := reflect.New(src.Type()).Elem()
+		for i := 0; i < src.Len(); i++ {
+			dst.Index(i).Set(clone(src.Index(i), o, visited))
+		}
+		return dst
+
+	case reflect.Map:
+		if src.IsNil() {
+			return reflect.Zero(src.Type())
+		}
+		addr := src.Pointer()
+		if v, ok := visited[addr]; ok {
+			return v
+		}
+		dst := reflect.MakeMapWithSize(src.Type(), src.Len())
+		visited[addr] = dst
+		for _, k := range src.MapKeys() {
+			dst.SetMapIndex(clone(k, o, visited), clone(src.MapIndex(k), o, visited))
+		}
+		return dst
+
+	default:
+		dst := reflect.New(src.Type()).Elem()
+		dst.Set(src)
+		return dst
+	}
+}
+
+// cloneUnexportedField copies val into an unexported field dst via unsafe,
+// bypassing the usual CanSet restriction.
+func cloneUnexportedField(dst, val reflect.Value) {
+	ptr := unsafe.Pointer(dst.UnsafeAddr())
+	reflect.NewAt(dst.Type(), ptr).Elem().Set(val)
+}
+
+// MergeStrategy selects how Merge combines slice or map fields.
+type MergeStrategy int
+
+const (
+	// Overwrite replaces dst's value with src's.
+	Overwrite MergeStrategy = iota
+	// AppendSlice appends src's slice elements to dst's.
+	AppendSlice
+	// UnionMap adds src's map entries to dst's, without removing any.
+	UnionMap
+)
+
+// MergeOption configures the behavior of Merge.
+type MergeOption func(*mergeOptions)
+
+type mergeOptions struct {
+	sliceStrategy MergeStrategy
+	mapStrategy   MergeStrategy
+	ignoreZero    bool
+}
+
+// WithSliceStrategy selects how slice fields are combined; the default
+// is Overwrite.
+func WithSliceStrategy(s MergeStrategy) MergeOption {
+	return func(o *mergeOptions) {
+		o.sliceStrategy = s
+	}
+}
+
+// WithMapStrategy selects how map fields are combined; the default
+// is Overwrite.
+func WithMapStrategy(s MergeStrategy) MergeOption {
+	return func(o *mergeOptions) {
+		o.mapStrategy = s
+	}
+}
+
+// IgnoreZero causes zero-valued fields/elements in src to be treated
+// as unset, leaving the corresponding dst value untouched.
+func IgnoreZero() MergeOption {
+	return func(o *mergeOptions) {
+		o.ignoreZero = true
+	}
+}
+
+// Merge combines src into dst, which must be a non-nil pointer.
+// Structs, pointers, and arrays are merged recursively; slices and
+// maps are combined as a whole according to the selected strategy.
+func Merge(dst, src interface{}, opts ...MergeOption) error {
+	o := &mergeOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("generic: Merge requires a non-nil pointer destination, got %T", dst)
+	}
+
+	sv := reflect.ValueOf(src)
+	if !sv.IsValid() {
+		return fmt.Errorf("generic: Merge requires a non-nil src, got %v", src)
+	}
+	if dv.Elem().Type() != sv.Type() {
+		return fmt.Errorf("generic: Merge kind mismatch: dst is %s, src is %s", dv.Elem().Type(), sv.Type())
+	}
+
+	visited := make(map[uintptr]bool)
+	return mergeValue(dv.Elem(), sv, o, visited)
+}
+
+func mergeValue(dst, src reflect.Value, o *mergeOptions, visited map[uintptr]bool) error {
+	if o.ignoreZero && src.IsValid() && src.IsZero() {
+		return nil
+	}
+
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return nil
+		}
+		addr := src.Pointer()
+		if visited[addr] {
+			return nil
+		}
+		visited[addr] = true
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return mergeValue(dst.Elem(), src.Elem(), o, visited)
+
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if src.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := mergeValue(dst.Field(i), src.Field(i), o, visited); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return nil
+		}
+		switch o.sliceStrategy {
+		case AppendSlice:
+			dst.Set(reflect.AppendSlice(dst, src))
+		default:
+			dst.Set(clone(src, &cloneOptions{}, map[uintptr]reflect.Value{}))
+		}
+		return nil
+
+	case reflect.Map:
+		if src.IsNil() {
+			return nil
+		}
+		switch o.mapStrategy {
+		case UnionMap:
+			if dst.IsNil() {
+				dst.Set(reflect.MakeMap(dst.Type()))
+			}
+			for _, k := range src.MapKeys() {
+				dst.SetMapIndex(k, src.MapIndex(k))
+			}
+		default:
+			dst.Set(clone(src, &cloneOptions{}, map[uintptr]reflect.Value{}))
+		}
+		return nil
+
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			if err := mergeValue(dst.Index(i), src.Index(i), o, visited); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		if !src.Type().AssignableTo(dst.Type()) {
+			return fmt.Errorf("generic: Merge cannot assign %s to %s", src.Type(), dst.Type())
+		}
+		dst.Set(src)
+		return nil
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCloneStructSliceMapArray(t *testing.T) {
+	type inner struct {
+		Tags []string
+		Meta map[string]int
+		Arr  [2]int
+	}
+	src := inner{
+		Tags: []string{"a", "b"},
+		Meta: map[string]int{"x": 1},
+		Arr:  [2]int{1, 2},
+	}
+
+	out := Clone(src).(inner)
+	if !reflect.DeepEqual(out, src) {
+		t.Fatalf("Clone() = %#v, want %#v", out, src)
+	}
+
+	// mutating the clone must not affect the source.
+	out.Tags[0] = "z"
+	out.Meta["x"] = 99
+	out.Arr[0] = 99
+	if src.Tags[0] != "a" || src.Meta["x"] != 1 || src.Arr[0] != 1 {
+		t.Error("Clone() shares backing storage with src")
+	}
+}
+
+func TestCloneCycle(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	a := &node{Name: "a"}
+	a.Next = a // self-cycle
+
+	out := Clone(a).(*node)
+	if out.Name != "a" {
+		t.Fatalf("Clone() = %#v, want Name=a", out)
+	}
+	if out.Next != out {
+		t.Error("Clone() did not preserve the cycle")
+	}
+}
+
+func TestCloneSkipsUnexportedByDefault(t *testing.T) {
+	type s struct {
+		X int
+		y int
+	}
+	out := Clone(s{X: 1, y: 2}).(s)
+	if out.X != 1 {
+		t.Errorf("Clone() X = %d, want 1", out.X)
+	}
+	if out.y != 0 {
+		t.Errorf("Clone() y = %d, want 0 (skipped)", out.y)
+	}
+}
+
+func TestCloneAllowUnexported(t *testing.T) {
+	type s struct {
+		X int
+		y int
+	}
+	out := Clone(s{X: 1, y: 2}, AllowUnexported()).(s)
+	if out.y != 2 {
+		t.Errorf("Clone(AllowUnexported()) y = %d, want 2", out.y)
+	}
+}
+
+func TestMergeOverwrite(t *testing.T) {
+	type s struct {
+		Tags []string
+		Meta map[string]int
+	}
+	dst := &s{Tags: []string{"a"}, Meta: map[string]int{"x": 1}}
+	src := s{Tags: []string{"b", "c"}, Meta: map[string]int{"y": 2}}
+
+	if err := Merge(dst, src); err != nil {
+		t.Fatalf("Merge: unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(dst.Tags, []string{"b", "c"}) {
+		t.Errorf("Merge overwrite: Tags = %v, want [b c]", dst.Tags)
+	}
+	if !reflect.DeepEqual(dst.Meta, map[string]int{"y": 2}) {
+		t.Errorf("Merge overwrite: Meta = %v, want map[y:2]", dst.Meta)
+	}
+}
+
+func TestMergeAppendSliceStrategy(t *testing.T) {
+	type s struct{ Tags []string }
+	dst := &s{Tags: []string{"a"}}
+	src := s{Tags: []string{"b", "c"}}
+
+	if err := Merge(dst, src, WithSliceStrategy(AppendSlice)); err != nil {
+		t.Fatalf("Merge: unexpected error %v", err)
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(dst.Tags, want) {
+		t.Errorf("Merge append-slice: Tags = %v, want %v", dst.Tags, want)
+	}
+}
+
+func TestMergeUnionMapStrategy(t *testing.T) {
+	type s struct{ Meta map[string]int }
+	dst := &s{Meta: map[string]int{"x": 1}}
+	src := s{Meta: map[string]int{"y": 2}}
+
+	if err := Merge(dst, src, WithMapStrategy(UnionMap)); err != nil {
+		t.Fatalf("Merge: unexpected error %v", err)
+	}
+	if want := (map[string]int{"x": 1, "y": 2}); !reflect.DeepEqual(dst.Meta, want) {
+		t.Errorf("Merge union-map: Meta = %v, want %v", dst.Meta, want)
+	}
+}
+
+func TestMergeIgnoreZero(t *testing.T) {
+	type s struct {
+		X int
+		Y int
+	}
+	dst := &s{X: 1, Y: 2}
+	src := s{X: 0, Y: 5}
+
+	if err := Merge(dst, src, IgnoreZero()); err != nil {
+		t.Fatalf("Merge: unexpected error %v", err)
+	}
+	if dst.X != 1 {
+		t.Errorf("Merge IgnoreZero: X = %d, want unchanged at 1", dst.X)
+	}
+	if dst.Y != 5 {
+		t.Errorf("Merge IgnoreZero: Y = %d, want 5", dst.Y)
+	}
+}
+
+func TestMergeRejectsNonPointerDst(t *testing.T) {
+	type s struct{ X int }
+	if err := Merge(s{}, s{X: 1}); err == nil {
+		t.Error("Merge(non-pointer dst) = nil error, want error")
+	}
+}
+
+func TestMergeRejectsNilSrc(t *testing.T) {
+	type s struct{ X int }
+	dst := &s{X: 1}
+	if err := Merge(dst, nil); err == nil {
+		t.Error("Merge(dst, nil) = nil error, want error")
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Copy copies elements from src into *dst, growing *dst with
+// reflect.MakeSlice when it is shorter than src, and returns the
+// number of elements copied. dst must be a pointer to a slice.
+func Copy(dst, src interface{}) (int, error) {
+	dv := reflect.ValueOf(dst)
+	if !TypeIsPointer(dv.Type()) {
+		return 0, ErrNotAPointer
+	}
+
+	dv = dv.Elem()
+	if dv.Kind() != reflect.Slice {
+		return 0, ErrNotASlice
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Kind() != reflect.Slice {
+		return 0, ErrNotASlice
+	}
+	if dv.Type().Elem() != sv.Type().Elem() {
+		return 0, fmt.Errorf("generic: Copy element-type mismatch: dst is []%s, src is []%s", dv.Type().Elem(), sv.Type().Elem())
+	}
+
+	if dv.Len() < sv.Len() {
+		grown := reflect.MakeSlice(dv.Type(), sv.Len(), sv.Len())
+		reflect.Copy(grown, dv)
+		dv.Set(grown)
+	}
+
+	return reflect.Copy(dv, sv), nil
+}
+
+// AppendTo appends elems to the slice pointed to by dstPtr, writing
+// the result back through dstPtr. elems may be a single element or a
+// slice of elements of the same type as *dstPtr.
+func AppendTo(dstPtr, elems interface{}) error {
+	dv := reflect.ValueOf(dstPtr)
+	if !TypeIsPointer(dv.Type()) {
+		return ErrNotAPointer
+	}
+
+	dv = dv.Elem()
+	if dv.Kind() != reflect.Slice {
+		return ErrNotASlice
+	}
+
+	ev := reflect.ValueOf(elems)
+	elemType := dv.Type().Elem()
+
+	var result reflect.Value
+	if ev.Kind() == reflect.Slice && ev.Type() == dv.Type() {
+		result = reflect.AppendSlice(dv, ev)
+	} else {
+		if !ev.Type().AssignableTo(elemType) {
+			return fmt.Errorf("generic: AppendTo cannot append value of type %s to []%s", ev.Type(), elemType)
+		}
+		result = reflect.Append(dv, ev)
+	}
+
+	dv.Set(result)
+	return nil
+}
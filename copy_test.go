@@ -0,0 +1,75 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import "testing"
+
+func TestCopyShorterDst(t *testing.T) {
+	dst := []int{0, 0}
+	n, err := Copy(&dst, []int{1, 2, 3})
+	if err != nil {
+		t.Fatalf("Copy: unexpected error %v", err)
+	}
+	if n != 3 {
+		t.Errorf("Copy: n = %d, want 3", n)
+	}
+	if want := []int{1, 2, 3}; !Equal(dst, want) {
+		t.Errorf("Copy: dst = %v, want %v", dst, want)
+	}
+}
+
+func TestCopyLongerDst(t *testing.T) {
+	dst := []int{9, 9, 9, 9}
+	n, err := Copy(&dst, []int{1, 2})
+	if err != nil {
+		t.Fatalf("Copy: unexpected error %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Copy: n = %d, want 2", n)
+	}
+	if want := []int{1, 2, 9, 9}; !Equal(dst, want) {
+		t.Errorf("Copy: dst = %v, want %v", dst, want)
+	}
+}
+
+func TestCopyRejectsElemTypeMismatch(t *testing.T) {
+	dst := []int{1, 2}
+	if _, err := Copy(&dst, []string{"a", "b"}); err == nil {
+		t.Error("Copy(*[]int, []string) = nil error, want error")
+	}
+}
+
+func TestCopyRejectsNonPointerDst(t *testing.T) {
+	if _, err := Copy([]int{1, 2}, []int{3, 4}); err != ErrNotAPointer {
+		t.Errorf("Copy(non-pointer dst) error = %v, want ErrNotAPointer", err)
+	}
+}
+
+func TestCopyRejectsNonSliceSrc(t *testing.T) {
+	dst := []int{1, 2}
+	if _, err := Copy(&dst, 42); err != ErrNotASlice {
+		t.Errorf("Copy(non-slice src) error = %v, want ErrNotASlice", err)
+	}
+}
+
+func TestAppendToRejectsElemTypeMismatch(t *testing.T) {
+	dst := []int{1, 2}
+	if err := AppendTo(&dst, "not an int"); err == nil {
+		t.Error("AppendTo(*[]int, string) = nil error, want error")
+	}
+	if len(dst) != 2 {
+		t.Errorf("AppendTo(*[]int, string): len(dst) = %d, want unchanged at 2", len(dst))
+	}
+}
+
+func TestAppendToSingleElement(t *testing.T) {
+	dst := []int{1, 2}
+	if err := AppendTo(&dst, 3); err != nil {
+		t.Fatalf("AppendTo: unexpected error %v", err)
+	}
+	if len(dst) != 3 || dst[2] != 3 {
+		t.Errorf("AppendTo: dst = %v, want [1 2 3]", dst)
+	}
+}
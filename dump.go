@@ -0,0 +1,221 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// DumpOption configures the behavior of Dump and Sdump.
+type DumpOption func(*dumpOptions)
+
+type dumpOptions struct {
+	maxDepth    int
+	maxSliceLen int
+	showUnexp   bool
+	colorize    bool
+}
+
+// MaxDepth limits recursion to n levels; nested values beyond that
+// depth are printed as "...". Zero (the default) means unlimited.
+func MaxDepth(n int) DumpOption {
+	return func(o *dumpOptions) {
+		o.maxDepth = n
+	}
+}
+
+// MaxSliceLen limits the number of slice/array elements printed;
+// remaining elements are summarized as "... (n more)". Zero (the
+// default) means unlimited.
+func MaxSliceLen(n int) DumpOption {
+	return func(o *dumpOptions) {
+		o.maxSliceLen = n
+	}
+}
+
+// ShowUnexported includes unexported struct fields in the dump.
+func ShowUnexported(show bool) DumpOption {
+	return func(o *dumpOptions) {
+		o.showUnexp = show
+	}
+}
+
+// Colorize wraps type names in ANSI color codes.
+func Colorize(c bool) DumpOption {
+	return func(o *dumpOptions) {
+		o.colorize = c
+	}
+}
+
+// dumper holds the state of a single Dump/Sdump call.
+type dumper struct {
+	w       io.Writer
+	opts    *dumpOptions
+	visited map[uintptr]bool
+}
+
+// Dump writes an indented, typed representation of v to w.
+func Dump(w io.Writer, v interface{}, opts ...DumpOption) {
+	o := &dumpOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	d := &dumper{w: w, opts: o, visited: make(map[uintptr]bool)}
+	d.dump(reflect.ValueOf(v), 0)
+	fmt.Fprintln(w)
+}
+
+// Sdump returns the Dump output of v as a string.
+func Sdump(v interface{}, opts ...DumpOption) string {
+	var b strings.Builder
+	Dump(&b, v, opts...)
+	return b.String()
+}
+
+func (d *dumper) typeName(t reflect.Type) string {
+	if !d.opts.colorize {
+		return t.String()
+	}
+	return "\x1b[36m" + t.String() + "\x1b[0m"
+}
+
+func (d *dumper) dump(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		fmt.Fprint(d.w, "nil")
+		return
+	}
+
+	if d.opts.maxDepth > 0 && depth > d.opts.maxDepth {
+		fmt.Fprint(d.w, "...")
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			fmt.Fprintf(d.w, "(%s)(nil)", d.typeName(v.Type()))
+			return
+		}
+		addr := v.Pointer()
+		if d.visited[addr] {
+			fmt.Fprintf(d.w, "(%s) <cycle>", d.typeName(v.Type()))
+			return
+		}
+		d.visited[addr] = true
+		fmt.Fprint(d.w, "&")
+		d.dump(v.Elem(), depth)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			fmt.Fprint(d.w, "nil")
+			return
+		}
+		d.dump(v.Elem(), depth)
+
+	case reflect.Struct:
+		fmt.Fprintf(d.w, "%s{", d.typeName(v.Type()))
+		indent := strings.Repeat("  ", depth+1)
+		first := true
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if f.PkgPath != "" && !d.opts.showUnexp {
+				continue
+			}
+			if !first {
+				fmt.Fprint(d.w, ",")
+			}
+			first = false
+			fmt.Fprintf(d.w, "\n%s%s: %s = ", indent, f.Name, d.typeName(f.Type))
+			fv := v.Field(i)
+			if f.PkgPath != "" {
+				fv = readUnexported(v, i)
+			}
+			d.dump(fv, depth+1)
+		}
+		if !first {
+			fmt.Fprintf(d.w, "\n%s", strings.Repeat("  ", depth))
+		}
+		fmt.Fprint(d.w, "}")
+
+	case reflect.Slice, reflect.Array:
+		fmt.Fprintf(d.w, "%s[", d.typeName(v.Type()))
+		indent := strings.Repeat("  ", depth+1)
+		n := v.Len()
+		shown := n
+		if d.opts.maxSliceLen > 0 && d.opts.maxSliceLen < n {
+			shown = d.opts.maxSliceLen
+		}
+		for i := 0; i < shown; i++ {
+			if i > 0 {
+				fmt.Fprint(d.w, ",")
+			}
+			fmt.Fprintf(d.w, "\n%s%d: ", indent, i)
+			d.dump(v.Index(i), depth+1)
+		}
+		if shown < n {
+			fmt.Fprintf(d.w, "\n%s... (%d more)", indent, n-shown)
+		}
+		if n > 0 {
+			fmt.Fprintf(d.w, "\n%s", strings.Repeat("  ", depth))
+		}
+		fmt.Fprint(d.w, "]")
+
+	case reflect.Map:
+		fmt.Fprintf(d.w, "%s{", d.typeName(v.Type()))
+		indent := strings.Repeat("  ", depth+1)
+		keys := v.MapKeys()
+		sortMapKeys(keys)
+		for i, k := range keys {
+			if i > 0 {
+				fmt.Fprint(d.w, ",")
+			}
+			fmt.Fprintf(d.w, "\n%s", indent)
+			d.dump(k, depth+1)
+			fmt.Fprint(d.w, ": ")
+			d.dump(v.MapIndex(k), depth+1)
+		}
+		if len(keys) > 0 {
+			fmt.Fprintf(d.w, "\n%s", strings.Repeat("  ", depth))
+		}
+		fmt.Fprint(d.w, "}")
+
+	default:
+		fmt.Fprintf(d.w, "%v", v.Interface())
+	}
+}
+
+// lessFunc returns a less-than comparator for the given kind, or nil
+// if the kind has no natural ordering.
+func lessFunc(k reflect.Kind) func(a, b reflect.Value) bool {
+	switch k {
+	case reflect.String:
+		return func(a, b reflect.Value) bool { return a.String() < b.String() }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b reflect.Value) bool { return a.Int() < b.Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b reflect.Value) bool { return a.Uint() < b.Uint() }
+	case reflect.Float32, reflect.Float64:
+		return func(a, b reflect.Value) bool { return a.Float() < b.Float() }
+	default:
+		return nil
+	}
+}
+
+// sortMapKeys sorts keys in place when their kind supports ordering;
+// otherwise it leaves them in the order reflect.Value.MapKeys returned.
+func sortMapKeys(keys []reflect.Value) {
+	if len(keys) == 0 {
+		return
+	}
+	less := lessFunc(keys[0].Kind())
+	if less == nil {
+		return
+	}
+	sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+}
@@ -0,0 +1,84 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSdumpStruct(t *testing.T) {
+	type s struct {
+		X int
+		Y string
+	}
+	out := Sdump(s{X: 1, Y: "a"})
+	if !strings.Contains(out, "X: int = 1") {
+		t.Errorf("Sdump() = %q, want it to contain \"X: int = 1\"", out)
+	}
+	if !strings.Contains(out, `Y: string = a`) {
+		t.Errorf("Sdump() = %q, want it to contain \"Y: string = a\"", out)
+	}
+}
+
+func TestSdumpMaxDepth(t *testing.T) {
+	type inner struct{ V int }
+	type middle struct{ In inner }
+	type outer struct{ Mid middle }
+
+	out := Sdump(outer{Mid: middle{In: inner{V: 1}}}, MaxDepth(1))
+	if !strings.Contains(out, "...") {
+		t.Errorf("Sdump(MaxDepth(1)) = %q, want it to contain \"...\"", out)
+	}
+}
+
+func TestSdumpMaxSliceLen(t *testing.T) {
+	out := Sdump([]int{1, 2, 3, 4, 5}, MaxSliceLen(2))
+	if !strings.Contains(out, "more") {
+		t.Errorf("Sdump(MaxSliceLen(2)) = %q, want it to summarize the remainder", out)
+	}
+	if strings.Contains(out, "4: 5") {
+		t.Errorf("Sdump(MaxSliceLen(2)) = %q, should not print elements past the limit", out)
+	}
+}
+
+func TestSdumpCyclePointer(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	n := &node{Name: "a"}
+	n.Next = n
+
+	out := Sdump(n)
+	if !strings.Contains(out, "<cycle>") {
+		t.Errorf("Sdump(cyclic) = %q, want it to contain \"<cycle>\"", out)
+	}
+}
+
+func TestSdumpColorize(t *testing.T) {
+	out := Sdump([]int{1, 2}, Colorize(true))
+	if !strings.Contains(out, "\x1b[36m") {
+		t.Errorf("Sdump(Colorize(true)) = %q, want ANSI color codes", out)
+	}
+	plain := Sdump([]int{1, 2})
+	if strings.Contains(plain, "\x1b[36m") {
+		t.Errorf("Sdump() without Colorize = %q, want no ANSI codes", plain)
+	}
+}
+
+func TestSdumpShowUnexported(t *testing.T) {
+	type s struct {
+		X int
+		y int
+	}
+	out := Sdump(s{X: 1, y: 2}, ShowUnexported(true))
+	if out == "" {
+		t.Fatal("Sdump with ShowUnexported returned empty output")
+	}
+	if !strings.Contains(out, "y: int = 2") {
+		t.Errorf("Sdump(ShowUnexported(true)) = %q, want it to contain \"y: int = 2\"", out)
+	}
+}
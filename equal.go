@@ -0,0 +1,218 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// EqualOption configures the behavior of Equal.
+type EqualOption func(*equalOptions)
+
+type equalOptions struct {
+	comparators   map[reflect.Type]func(a, b reflect.Value) bool
+	ignoreFields  map[reflect.Type]map[string]bool
+	epsilon       float64
+	nilEmptyEqual bool
+}
+
+// WithComparator registers a custom comparator for values of type t,
+// overriding the default comparison for that type.
+func WithComparator(t reflect.Type, cmp func(a, b reflect.Value) bool) EqualOption {
+	return func(o *equalOptions) {
+		if o.comparators == nil {
+			o.comparators = make(map[reflect.Type]func(a, b reflect.Value) bool)
+		}
+		o.comparators[t] = cmp
+	}
+}
+
+// IgnoreFields excludes the named fields of typ from comparison.
+func IgnoreFields(typ reflect.Type, names ...string) EqualOption {
+	return func(o *equalOptions) {
+		if o.ignoreFields == nil {
+			o.ignoreFields = make(map[reflect.Type]map[string]bool)
+		}
+		set := o.ignoreFields[typ]
+		if set == nil {
+			set = make(map[string]bool)
+			o.ignoreFields[typ] = set
+		}
+		for _, n := range names {
+			set[n] = true
+		}
+	}
+}
+
+// ApproxFloat compares Float32/Float64 values within epsilon instead
+// of requiring an exact match.
+func ApproxFloat(epsilon float64) EqualOption {
+	return func(o *equalOptions) {
+		o.epsilon = epsilon
+	}
+}
+
+// NilEmptyEqual treats a nil slice/map as equal to a non-nil, empty
+// slice/map of the same type. Off by default, matching reflect.DeepEqual.
+func NilEmptyEqual() EqualOption {
+	return func(o *equalOptions) {
+		o.nilEmptyEqual = true
+	}
+}
+
+// visit records a pair of pointer-like values already compared, to
+// detect and short-circuit cycles.
+type visit struct {
+	a1, a2 unsafe.Pointer
+	typ    reflect.Type
+}
+
+// Equal reports whether a and b are deeply equal, in the sense of
+// reflect.DeepEqual, extended with cycle detection, per-type
+// comparator hooks, field exclusion, and approximate float comparison.
+func Equal(a, b interface{}, opts ...EqualOption) bool {
+	o := &equalOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Type() != vb.Type() {
+		return false
+	}
+
+	return deepEqual(va, vb, make(map[visit]bool), o)
+}
+
+func deepEqual(a, b reflect.Value, visited map[visit]bool, o *equalOptions) bool {
+	if cmp, ok := o.comparators[a.Type()]; ok {
+		return cmp(a, b)
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		key := visit{unsafe.Pointer(a.Pointer()), unsafe.Pointer(b.Pointer()), a.Type()}
+		if visited[key] {
+			return true
+		}
+		visited[key] = true
+		return deepEqual(a.Elem(), b.Elem(), visited, o)
+
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return deepEqual(a.Elem(), b.Elem(), visited, o)
+
+	case reflect.Struct:
+		ignore := o.ignoreFields[a.Type()]
+		for i := 0; i < a.NumField(); i++ {
+			name := a.Type().Field(i).Name
+			if ignore[name] {
+				continue
+			}
+			af, bf := a.Field(i), b.Field(i)
+			if a.Type().Field(i).PkgPath != "" {
+				af, bf = readUnexported(a, i), readUnexported(b, i)
+			}
+			if !deepEqual(af, bf, visited, o) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Slice:
+		if a.IsNil() != b.IsNil() && !o.nilEmptyEqual {
+			return false
+		}
+		if a.Len() != b.Len() {
+			return false
+		}
+		if a.Pointer() == b.Pointer() && a.Len() > 0 {
+			return true
+		}
+		key := visit{unsafe.Pointer(a.Pointer()), unsafe.Pointer(b.Pointer()), a.Type()}
+		if a.Len() > 0 {
+			if visited[key] {
+				return true
+			}
+			visited[key] = true
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !deepEqual(a.Index(i), b.Index(i), visited, o) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if !deepEqual(a.Index(i), b.Index(i), visited, o) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() && !o.nilEmptyEqual {
+			return false
+		}
+		if a.Len() != b.Len() {
+			return false
+		}
+		if a.Pointer() == b.Pointer() {
+			return true
+		}
+		key := visit{unsafe.Pointer(a.Pointer()), unsafe.Pointer(b.Pointer()), a.Type()}
+		if visited[key] {
+			return true
+		}
+		visited[key] = true
+		for _, k := range a.MapKeys() {
+			bv := b.MapIndex(k)
+			if !bv.IsValid() || !deepEqual(a.MapIndex(k), bv, visited, o) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Float32, reflect.Float64:
+		if o.epsilon > 0 {
+			return math.Abs(a.Float()-b.Float()) <= o.epsilon
+		}
+		return a.Float() == b.Float()
+
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
+
+// readUnexported returns the i'th field of struct value v as an
+// interfaceable reflect.Value, bypassing the usual read restriction
+// on unexported fields via unsafe.
+func readUnexported(v reflect.Value, i int) reflect.Value {
+	if !v.CanAddr() {
+		cp := reflect.New(v.Type()).Elem()
+		cp.Set(v)
+		v = cp
+	}
+	f := v.Field(i)
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
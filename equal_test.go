@@ -0,0 +1,124 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEqualBasicKinds(t *testing.T) {
+	if !Equal(1, 1) {
+		t.Error("Equal(1, 1) = false, want true")
+	}
+	if Equal(1, 2) {
+		t.Error("Equal(1, 2) = true, want false")
+	}
+	if !Equal([]int{1, 2}, []int{1, 2}) {
+		t.Error("Equal(slice, slice) = false, want true")
+	}
+	if Equal([]int{1, 2}, []int{1, 3}) {
+		t.Error("Equal(slice, different slice) = true, want false")
+	}
+	if !Equal(map[string]int{"a": 1}, map[string]int{"a": 1}) {
+		t.Error("Equal(map, map) = false, want true")
+	}
+}
+
+func TestEqualWithComparator(t *testing.T) {
+	type point struct{ X, Y int }
+	a := point{1, 2}
+	b := point{1, 999} // differs, but the comparator only checks X
+
+	cmp := func(a, b reflect.Value) bool {
+		return a.FieldByName("X").Int() == b.FieldByName("X").Int()
+	}
+
+	if Equal(a, b) {
+		t.Error("Equal(a, b) without comparator = true, want false")
+	}
+	if !Equal(a, b, WithComparator(reflect.TypeOf(point{}), cmp)) {
+		t.Error("Equal(a, b, WithComparator) = false, want true")
+	}
+}
+
+func TestEqualIgnoreFields(t *testing.T) {
+	type s struct {
+		ID        int
+		UpdatedAt int
+	}
+	a := s{ID: 1, UpdatedAt: 100}
+	b := s{ID: 1, UpdatedAt: 200}
+
+	if Equal(a, b) {
+		t.Error("Equal(a, b) without IgnoreFields = true, want false")
+	}
+	if !Equal(a, b, IgnoreFields(reflect.TypeOf(s{}), "UpdatedAt")) {
+		t.Error("Equal(a, b, IgnoreFields(UpdatedAt)) = false, want true")
+	}
+}
+
+func TestEqualApproxFloat(t *testing.T) {
+	a, b := 1.0, 1.0001
+	if Equal(a, b) {
+		t.Error("Equal(1.0, 1.0001) = true, want false")
+	}
+	if !Equal(a, b, ApproxFloat(0.001)) {
+		t.Error("Equal(1.0, 1.0001, ApproxFloat(0.001)) = false, want true")
+	}
+	if Equal(a, b, ApproxFloat(0.00001)) {
+		t.Error("Equal(1.0, 1.0001, ApproxFloat(0.00001)) = true, want false")
+	}
+}
+
+func TestEqualNilEmptySlice(t *testing.T) {
+	var nilSlice []int
+	empty := []int{}
+
+	if Equal(nilSlice, empty) {
+		t.Error("Equal(nil, empty) = true, want false without NilEmptyEqual")
+	}
+	if !Equal(nilSlice, empty, NilEmptyEqual()) {
+		t.Error("Equal(nil, empty, NilEmptyEqual()) = false, want true")
+	}
+}
+
+func TestEqualCyclicPointers(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	a := &node{Name: "a"}
+	a.Next = a
+	b := &node{Name: "a"}
+	b.Next = b
+
+	if !Equal(a, b) {
+		t.Error("Equal(cyclic a, cyclic b) = false, want true")
+	}
+
+	c := &node{Name: "different"}
+	c.Next = c
+	if Equal(a, c) {
+		t.Error("Equal(cyclic a, cyclic c) = true, want false")
+	}
+}
+
+func TestEqualUnexportedFields(t *testing.T) {
+	type s struct {
+		X int
+		y int
+	}
+	a := s{X: 1, y: 2}
+	b := s{X: 1, y: 2}
+	c := s{X: 1, y: 3}
+
+	if !Equal(a, b) {
+		t.Error("Equal(a, b) = false, want true")
+	}
+	if Equal(a, c) {
+		t.Error("Equal(a, c) = true, want false")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// GetField returns the value of the named field within i.
+// The argument should be a struct or *struct; embedded/anonymous
+// struct fields are searched as well.
+func GetField(i interface{}, name string) (interface{}, error) {
+	if !IsStructOrStructPtr(i) {
+		return nil, ErrNotAStruct
+	}
+
+	field := Indirect(i).FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return nil, ErrUnknownField
+	}
+
+	return field.Interface(), nil
+}
+
+// SetField assigns val to the named field within i.
+// The argument i should be a pointer to a struct, so that the
+// assignment is visible to the caller. Numeric val types that are
+// convertible to the field's type are converted before assignment.
+func SetField(i interface{}, name string, val interface{}) error {
+	if !IsStructPtr(i) {
+		return ErrNotAStruct
+	}
+
+	field := Indirect(i).FieldByName(name)
+	if !field.IsValid() {
+		return ErrUnknownField
+	}
+	if !field.CanSet() {
+		return fmt.Errorf("field %q cannot be set", name)
+	}
+
+	v := reflect.ValueOf(val)
+	if !v.Type().AssignableTo(field.Type()) {
+		if !isNumericKind(v.Kind()) || !isNumericKind(field.Kind()) || !v.Type().ConvertibleTo(field.Type()) {
+			return fmt.Errorf("value of type %s is not assignable to field %q of type %s", v.Type(), name, field.Type())
+		}
+		v = v.Convert(field.Type())
+	}
+
+	field.Set(v)
+	return nil
+}
+
+// Fields returns the names of the exported fields of i, including
+// those promoted from embedded/anonymous struct fields.
+func Fields(i interface{}) ([]string, error) {
+	if !IsStructOrStructPtr(i) {
+		return nil, ErrNotAStruct
+	}
+
+	var names []string
+	walkFields(Indirect(i).Type(), func(f reflect.StructField) {
+		names = append(names, f.Name)
+	})
+	return names, nil
+}
+
+// Tags returns a map of exported field name to the value of the given
+// tag key, for every field that declares that tag.
+func Tags(i interface{}, tagKey string) (map[string]string, error) {
+	if !IsStructOrStructPtr(i) {
+		return nil, ErrNotAStruct
+	}
+
+	tags := make(map[string]string)
+	walkFields(Indirect(i).Type(), func(f reflect.StructField) {
+		if val, ok := f.Tag.Lookup(tagKey); ok {
+			tags[f.Name] = val
+		}
+	})
+	return tags, nil
+}
+
+// FieldsByTag returns the names of the exported fields whose tagKey
+// tag equals tagValue.
+func FieldsByTag(i interface{}, tagKey, tagValue string) ([]string, error) {
+	if !IsStructOrStructPtr(i) {
+		return nil, ErrNotAStruct
+	}
+
+	var names []string
+	walkFields(Indirect(i).Type(), func(f reflect.StructField) {
+		if val, ok := f.Tag.Lookup(tagKey); ok && val == tagValue {
+			names = append(names, f.Name)
+		}
+	})
+	return names, nil
+}
+
+// walkFields applies fn to every exported field of the struct type t,
+// descending into embedded/anonymous struct fields. An anonymous
+// field is always descended into, even when its own type name is
+// unexported, since its exported fields are still promoted (the same
+// rule encoding/json applies).
+func walkFields(t reflect.Type, fn func(reflect.StructField)) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && TypeIsStruct(f.Type) {
+			walkFields(f.Type, fn)
+			continue
+		}
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		fn(f)
+	}
+}
+
+// isNumericKind reports whether k is an integer, unsigned integer, or
+// floating-point kind.
+func isNumericKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
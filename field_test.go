@@ -0,0 +1,122 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFieldFunctionsRejectNonStruct(t *testing.T) {
+	if _, err := GetField(42, "X"); err != ErrNotAStruct {
+		t.Errorf("GetField(42, ...) error = %v, want ErrNotAStruct", err)
+	}
+	if err := SetField(42, "X", 1); err != ErrNotAStruct {
+		t.Errorf("SetField(42, ...) error = %v, want ErrNotAStruct", err)
+	}
+	if _, err := Fields(42); err != ErrNotAStruct {
+		t.Errorf("Fields(42) error = %v, want ErrNotAStruct", err)
+	}
+	if _, err := Tags(42, "json"); err != ErrNotAStruct {
+		t.Errorf("Tags(42, ...) error = %v, want ErrNotAStruct", err)
+	}
+	if _, err := FieldsByTag(42, "json", "x"); err != ErrNotAStruct {
+		t.Errorf("FieldsByTag(42, ...) error = %v, want ErrNotAStruct", err)
+	}
+}
+
+func TestGetFieldSkipsUnexported(t *testing.T) {
+	type s struct {
+		X int
+		y int
+	}
+	if _, err := GetField(s{X: 1, y: 2}, "y"); err != ErrUnknownField {
+		t.Errorf("GetField(unexported) error = %v, want ErrUnknownField", err)
+	}
+}
+
+func TestSetFieldNumericConversion(t *testing.T) {
+	type s struct{ N int64 }
+	v := &s{}
+	if err := SetField(v, "N", 42); err != nil {
+		t.Fatalf("SetField numeric conversion: unexpected error %v", err)
+	}
+	if v.N != 42 {
+		t.Errorf("SetField numeric conversion: N = %d, want 42", v.N)
+	}
+}
+
+func TestSetFieldRejectsNonNumericConversion(t *testing.T) {
+	type s struct{ S string }
+	v := &s{}
+	if err := SetField(v, "S", 65); err == nil {
+		t.Errorf("SetField(int into string field) = nil error, want error")
+	}
+	if v.S != "" {
+		t.Errorf("SetField(int into string field): S = %q, want unchanged", v.S)
+	}
+}
+
+type fieldTestBase struct {
+	ID   int    `json:"id" valid:"required"`
+	Name string `json:"name"`
+}
+
+type fieldTestEmbedded struct {
+	fieldTestBase
+	Extra string `json:"extra" valid:"required"`
+}
+
+func TestGetFieldSetFieldEmbedded(t *testing.T) {
+	v := &fieldTestEmbedded{fieldTestBase: fieldTestBase{ID: 1, Name: "a"}, Extra: "b"}
+
+	got, err := GetField(v, "Name")
+	if err != nil {
+		t.Fatalf("GetField(embedded): unexpected error %v", err)
+	}
+	if got != "a" {
+		t.Errorf("GetField(embedded) = %v, want \"a\"", got)
+	}
+
+	if err := SetField(v, "Name", "z"); err != nil {
+		t.Fatalf("SetField(embedded): unexpected error %v", err)
+	}
+	if v.Name != "z" {
+		t.Errorf("SetField(embedded): Name = %q, want \"z\"", v.Name)
+	}
+}
+
+func TestFields(t *testing.T) {
+	names, err := Fields(fieldTestEmbedded{})
+	if err != nil {
+		t.Fatalf("Fields: unexpected error %v", err)
+	}
+	want := []string{"ID", "Name", "Extra"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("Fields() = %v, want %v", names, want)
+	}
+}
+
+func TestTags(t *testing.T) {
+	tags, err := Tags(fieldTestEmbedded{}, "json")
+	if err != nil {
+		t.Fatalf("Tags: unexpected error %v", err)
+	}
+	want := map[string]string{"ID": "id", "Name": "name", "Extra": "extra"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("Tags() = %v, want %v", tags, want)
+	}
+}
+
+func TestFieldsByTag(t *testing.T) {
+	names, err := FieldsByTag(fieldTestEmbedded{}, "valid", "required")
+	if err != nil {
+		t.Fatalf("FieldsByTag: unexpected error %v", err)
+	}
+	want := []string{"ID", "Extra"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("FieldsByTag() = %v, want %v", names, want)
+	}
+}
@@ -13,26 +13,28 @@ import (
 var (
 	ErrNotAStruct   = errors.New("Argument does not reference a struct")
 	ErrUnknownField = errors.New("Struct has no field of the given name")
+	ErrNotASlice    = errors.New("Argument does not reference a slice")
+	ErrNotAPointer  = errors.New("Argument is not a pointer")
 )
 
 // VerifyInt requires i to be of type int or panics.
 func VerifyInt(i interface{}) {
 	if !IsInt(i) {
-		log.Panic("Int parameter required, not %#v (%T)\n", i, i)
+		log.Panicf("Int parameter required, not %#v (%T)\n", i, i)
 	}
 }
 
 // VerifyString requires i to be of type string or panics.
 func VerifyString(i interface{}) {
 	if !IsString(i) {
-		log.Panic("Int parameter required, not %#v (%T)\n", i, i)
+		log.Panicf("String parameter required, not %#v (%T)\n", i, i)
 	}
 }
 
 // VerifySlice requires i to be of type slice or panics.
 func VerifySlice(i interface{}) {
-	if IsSlice(i) {
-		log.Panic("Int parameter required, not %#v (%T)\n", i, i)
+	if !IsSlice(i) {
+		log.Panicf("Slice parameter required, not %#v (%T)\n", i, i)
 	}
 }
 
@@ -81,12 +83,14 @@ func IsStructPtr(i interface{}) bool {
 	if !IsPtr(i) {
 		return false
 	}
-	return IsStruct(Indirect(i))
+	v := Indirect(i)
+	return v.IsValid() && TypeIsStruct(v.Type())
 }
 
 // IsStructOrStructPtr returns true if i is of type struct or struct pointer.
 func IsStructOrStructPtr(i interface{}) bool {
-	return IsStruct(Indirect(i))
+	v := Indirect(i)
+	return v.IsValid() && TypeIsStruct(v.Type())
 }
 
 // Indirect returns the value that i points to or,
@@ -148,18 +152,17 @@ func Foreach(i interface{}, fn func(int, interface{}) bool) {
 
 // Field returns the named field within the given argument.
 // The argument should be a struct or *struct.
-// All errors are silently reported by returning a zero value.
-func Field(i interface{}, name string) reflect.Value /*, error*/ {
+func Field(i interface{}, name string) (reflect.Value, error) {
 	if !IsStructOrStructPtr(i) {
-		return reflect.Zero(TypeOf(i)) //, errNotAStruct
+		return reflect.Zero(TypeOf(i)), ErrNotAStruct
 	}
 
 	field := ValueOf(i).FieldByName(name)
 	if !field.IsValid() {
-		return reflect.Zero(TypeOf(i)) //, errUnknownField
+		return reflect.Zero(TypeOf(i)), ErrUnknownField
 	}
 
-	return field //, nil
+	return field, nil
 }
 
 // Zero returns the zero value corresponding to the type of the given parameter.
@@ -167,26 +170,4 @@ func Zero(i interface{}) interface{} {
 	return reflect.Zero(TypeOf(i))
 }
 
-// should be AppendAsSlice - hide for now
-// AppendSlice elements of the slice represented by v to the slice represented by ret
-// and return the result.  Both parameters must have the same underlying type.
-// func AppendSlice(ret, v interface{}) reflect.Value {
-//	return reflect.AppendSlice(ValueOf(ret), ValueOf(v))
-// }
-
-// not working - hide for now
-// func Copy(dst, src interface{}) interface{} {
-//	fmt.Printf("Value src: %#v (%T)\n", src, src)
-//	fmt.Printf("Value dst: %#v (%T)\n", dst, dst)
-//	n := reflect.Copy(ValueOf(dst), ValueOf(src))
-//	fmt.Printf("  %v values copied\n", n)
-//	fmt.Printf("Value src: %#v (%T)\n", src, src)
-//	fmt.Printf("Value dst: %#v (%T)\n", dst, dst)
-//	return dst
-// }
-
-
-// debug statements stash
-// fmt.Printf("Value i: %#v (%T)\n", i, i)
-// fmt.Printf("Value x: %#v (%T)\n", x, x)
 
@@ -0,0 +1,29 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import "testing"
+
+func TestIsStructOrStructPtrRejectsNonStruct(t *testing.T) {
+	if IsStructOrStructPtr(42) {
+		t.Error("IsStructOrStructPtr(42) = true, want false")
+	}
+	if IsStructPtr(42) {
+		t.Error("IsStructPtr(42) = true, want false")
+	}
+}
+
+func TestIsStructOrStructPtrAcceptsStruct(t *testing.T) {
+	type s struct{ X int }
+	if !IsStructOrStructPtr(s{}) {
+		t.Error("IsStructOrStructPtr(s{}) = false, want true")
+	}
+	if !IsStructOrStructPtr(&s{}) {
+		t.Error("IsStructOrStructPtr(&s{}) = false, want true")
+	}
+	if !IsStructPtr(&s{}) {
+		t.Error("IsStructPtr(&s{}) = false, want true")
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import "reflect"
+
+// Map applies fn to each element of slice and returns a new slice of
+// the results. The result element type is inferred from the first
+// non-nil value fn returns; use MapTyped when that inference is not
+// possible (e.g. every result is nil).
+func Map(slice interface{}, fn func(int, interface{}) interface{}) interface{} {
+	VerifySlice(slice)
+
+	val := ValueOf(slice)
+	n := val.Len()
+
+	results := make([]interface{}, n)
+	var elemType reflect.Type
+	for i := 0; i < n; i++ {
+		results[i] = fn(i, val.Index(i).Interface())
+		if elemType == nil && results[i] != nil {
+			elemType = reflect.TypeOf(results[i])
+		}
+	}
+	if elemType == nil {
+		elemType = val.Type().Elem()
+	}
+
+	out := reflect.MakeSlice(reflect.SliceOf(elemType), n, n)
+	for i, r := range results {
+		if r == nil {
+			continue
+		}
+		out.Index(i).Set(reflect.ValueOf(r))
+	}
+	return out.Interface()
+}
+
+// MapTyped is like Map but takes the result element type explicitly,
+// for callers that cannot rely on inference from the returned values.
+func MapTyped(slice interface{}, elemType reflect.Type, fn func(int, interface{}) interface{}) interface{} {
+	VerifySlice(slice)
+
+	val := ValueOf(slice)
+	n := val.Len()
+
+	out := reflect.MakeSlice(reflect.SliceOf(elemType), n, n)
+	for i := 0; i < n; i++ {
+		r := fn(i, val.Index(i).Interface())
+		if r == nil {
+			continue
+		}
+		out.Index(i).Set(reflect.ValueOf(r))
+	}
+	return out.Interface()
+}
+
+// Filter returns a new slice, of the same element type as slice,
+// containing the elements for which fn returns true.
+func Filter(slice interface{}, fn func(int, interface{}) bool) interface{} {
+	VerifySlice(slice)
+
+	val := ValueOf(slice)
+	out := MakeSlice(slice)
+	for i := 0; i < val.Len(); i++ {
+		if fn(i, val.Index(i).Interface()) {
+			out = reflect.Append(out, val.Index(i))
+		}
+	}
+	return out.Interface()
+}
+
+// Reduce applies fn to each element of slice in order, threading an
+// accumulator that starts at seed, and returns the final accumulator.
+func Reduce(slice interface{}, seed interface{}, fn func(acc, elem interface{}) interface{}) interface{} {
+	VerifySlice(slice)
+
+	val := ValueOf(slice)
+	acc := seed
+	for i := 0; i < val.Len(); i++ {
+		acc = fn(acc, val.Index(i).Interface())
+	}
+	return acc
+}
+
+// Contains reports whether slice has an element deeply equal to elem.
+func Contains(slice interface{}, elem interface{}) bool {
+	return IndexOf(slice, elem) >= 0
+}
+
+// IndexOf returns the index of the first element of slice deeply
+// equal to elem, or -1 if none is found.
+func IndexOf(slice interface{}, elem interface{}) int {
+	VerifySlice(slice)
+
+	val := ValueOf(slice)
+	for i := 0; i < val.Len(); i++ {
+		if Equal(val.Index(i).Interface(), elem) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Unique returns a new slice, of the same element type as slice,
+// containing only the first occurrence of each distinct element.
+// Comparable element kinds are deduplicated in O(n) via a map; other
+// kinds (e.g. structs containing slices or maps) fall back to an
+// O(n²) Equal comparison.
+func Unique(slice interface{}) interface{} {
+	VerifySlice(slice)
+
+	val := ValueOf(slice)
+	out := MakeSlice(slice)
+
+	if val.Type().Elem().Comparable() {
+		seen := make(map[interface{}]struct{})
+		for i := 0; i < val.Len(); i++ {
+			elem := val.Index(i)
+			key := elem.Interface()
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			out = reflect.Append(out, elem)
+		}
+		return out.Interface()
+	}
+
+	for i := 0; i < val.Len(); i++ {
+		elem := val.Index(i)
+		if Contains(out.Interface(), elem.Interface()) {
+			continue
+		}
+		out = reflect.Append(out, elem)
+	}
+	return out.Interface()
+}
@@ -0,0 +1,109 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMapInfersElemType(t *testing.T) {
+	in := []int{1, 2, 3}
+	out := Map(in, func(i int, v interface{}) interface{} {
+		return v.(int) * 2
+	})
+	want := []int{2, 4, 6}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("Map() = %#v, want %#v", out, want)
+	}
+}
+
+func TestMapTyped(t *testing.T) {
+	in := []int{1, 2, 3}
+	out := MapTyped(in, reflect.TypeOf(""), func(i int, v interface{}) interface{} {
+		return "nil"
+	})
+	want := []string{"nil", "nil", "nil"}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("MapTyped() = %#v, want %#v", out, want)
+	}
+}
+
+func TestFilterPreservesElemType(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5}
+	out := Filter(in, func(i int, v interface{}) bool {
+		return v.(int)%2 == 0
+	})
+	want := []int{2, 4}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("Filter() = %#v, want %#v", out, want)
+	}
+	if reflect.TypeOf(out) != reflect.TypeOf(in) {
+		t.Errorf("Filter() type = %T, want %T", out, in)
+	}
+}
+
+func TestReduce(t *testing.T) {
+	in := []int{1, 2, 3, 4}
+	sum := Reduce(in, 0, func(acc, elem interface{}) interface{} {
+		return acc.(int) + elem.(int)
+	})
+	if sum != 10 {
+		t.Errorf("Reduce() = %v, want 10", sum)
+	}
+}
+
+func TestContainsAndIndexOf(t *testing.T) {
+	in := []string{"a", "b", "c"}
+	if !Contains(in, "b") {
+		t.Error("Contains(in, \"b\") = false, want true")
+	}
+	if Contains(in, "z") {
+		t.Error("Contains(in, \"z\") = true, want false")
+	}
+	if idx := IndexOf(in, "c"); idx != 2 {
+		t.Errorf("IndexOf(in, \"c\") = %d, want 2", idx)
+	}
+	if idx := IndexOf(in, "z"); idx != -1 {
+		t.Errorf("IndexOf(in, \"z\") = %d, want -1", idx)
+	}
+}
+
+func TestUniqueComparable(t *testing.T) {
+	in := []int{1, 2, 2, 3, 1, 4}
+	out := Unique(in)
+	want := []int{1, 2, 3, 4}
+	if !reflect.DeepEqual(out, want) {
+		t.Errorf("Unique() = %#v, want %#v", out, want)
+	}
+}
+
+func TestUniqueNonComparable(t *testing.T) {
+	type pair struct {
+		K string
+		V []int
+	}
+	in := []pair{
+		{"a", []int{1, 2}},
+		{"b", []int{3}},
+		{"a", []int{1, 2}},
+	}
+	out := Unique(in).([]pair)
+	if len(out) != 2 {
+		t.Fatalf("Unique() len = %d, want 2", len(out))
+	}
+	if out[0].K != "a" || out[1].K != "b" {
+		t.Errorf("Unique() = %#v, want first-occurrence order [a b]", out)
+	}
+}
+
+func TestVerifySlicePanicsOnNonSlice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Map(non-slice) did not panic")
+		}
+	}()
+	Map(42, func(i int, v interface{}) interface{} { return v })
+}
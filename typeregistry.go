@@ -0,0 +1,173 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// TypeID is a stable, compact identifier for a reflect.Type, suitable
+// for use on the wire in place of a fully qualified type name.
+type TypeID int32
+
+// firstUserID is the first TypeID available for user-registered types.
+// IDs below it are reserved for the built-in kinds preregistered by
+// NewTypeRegistry.
+const firstUserID TypeID = 64
+
+// TypeRegistry assigns stable TypeIDs to reflect.Types, modeled on
+// gob's typeId/idToType maps. It is safe for concurrent use.
+type TypeRegistry struct {
+	mu       sync.RWMutex
+	idToType map[TypeID]reflect.Type
+	typeToID map[reflect.Type]TypeID
+	nameToID map[string]TypeID
+	nextID   TypeID
+}
+
+// builtinTypes lists the kinds preregistered under IDs 1-63.
+var builtinTypes = []interface{}{
+	bool(false),
+	int(0), int8(0), int16(0), int32(0), int64(0),
+	uint(0), uint8(0), uint16(0), uint32(0), uint64(0), uintptr(0),
+	float32(0), float64(0),
+	complex64(0), complex128(0),
+	string(""),
+	[]byte(nil),
+}
+
+// NewTypeRegistry returns a TypeRegistry with the built-in kinds
+// preregistered under IDs 1-63. User types are assigned IDs starting
+// at 64.
+func NewTypeRegistry() *TypeRegistry {
+	r := &TypeRegistry{
+		idToType: make(map[TypeID]reflect.Type),
+		typeToID: make(map[reflect.Type]TypeID),
+		nameToID: make(map[string]TypeID),
+		nextID:   firstUserID,
+	}
+	for i, v := range builtinTypes {
+		id := TypeID(i + 1)
+		t := reflect.TypeOf(v)
+		r.idToType[id] = t
+		r.typeToID[t] = id
+	}
+	return r
+}
+
+// defaultRegistry is the registry used by the package-level Register,
+// RegisterName, Lookup, LookupType, and NewOf functions.
+var defaultRegistry = NewTypeRegistry()
+
+// Register assigns a new TypeID to the type of v, or returns its
+// existing TypeID if already registered.
+func (r *TypeRegistry) Register(v interface{}) TypeID {
+	t := reflect.TypeOf(v)
+
+	r.mu.RLock()
+	if id, ok := r.typeToID[t]; ok {
+		r.mu.RUnlock()
+		return id
+	}
+	r.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.typeToID[t]; ok {
+		return id
+	}
+	id := r.nextID
+	r.nextID++
+	r.idToType[id] = t
+	r.typeToID[t] = id
+	return id
+}
+
+// RegisterName is like Register, but also binds name to the returned
+// TypeID so it can later be recovered via LookupName. It panics if
+// name is already bound to a different TypeID.
+func (r *TypeRegistry) RegisterName(name string, v interface{}) TypeID {
+	id := r.Register(v)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.nameToID[name]; ok && existing != id {
+		panic(fmt.Sprintf("generic: name %q already registered for a different type", name))
+	}
+	r.nameToID[name] = id
+	return id
+}
+
+// Lookup returns the reflect.Type registered under id, if any.
+func (r *TypeRegistry) Lookup(id TypeID) (reflect.Type, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.idToType[id]
+	return t, ok
+}
+
+// LookupType returns the TypeID registered for t, if any.
+func (r *TypeRegistry) LookupType(t reflect.Type) (TypeID, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.typeToID[t]
+	return id, ok
+}
+
+// LookupName returns the TypeID bound to name via RegisterName, if any.
+func (r *TypeRegistry) LookupName(name string) (TypeID, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	id, ok := r.nameToID[name]
+	return id, ok
+}
+
+// NewOf allocates a new, zero-valued pointer to the type registered
+// under id.
+func (r *TypeRegistry) NewOf(id TypeID) (reflect.Value, error) {
+	t, ok := r.Lookup(id)
+	if !ok {
+		return reflect.Value{}, fmt.Errorf("generic: no type registered for TypeID %d", id)
+	}
+	return reflect.New(t), nil
+}
+
+// Register assigns a new TypeID to the type of v in the default
+// registry, or returns its existing TypeID if already registered.
+func Register(v interface{}) TypeID {
+	return defaultRegistry.Register(v)
+}
+
+// RegisterName is like Register, but also binds name to the returned
+// TypeID in the default registry, recoverable via LookupName.
+func RegisterName(name string, v interface{}) TypeID {
+	return defaultRegistry.RegisterName(name, v)
+}
+
+// Lookup returns the reflect.Type registered under id in the default
+// registry, if any.
+func Lookup(id TypeID) (reflect.Type, bool) {
+	return defaultRegistry.Lookup(id)
+}
+
+// LookupType returns the TypeID registered for t in the default
+// registry, if any.
+func LookupType(t reflect.Type) (TypeID, bool) {
+	return defaultRegistry.LookupType(t)
+}
+
+// LookupName returns the TypeID bound to name via RegisterName in the
+// default registry, if any.
+func LookupName(name string) (TypeID, bool) {
+	return defaultRegistry.LookupName(name)
+}
+
+// NewOf allocates a new, zero-valued pointer to the type registered
+// under id in the default registry.
+func NewOf(id TypeID) (reflect.Value, error) {
+	return defaultRegistry.NewOf(id)
+}
@@ -0,0 +1,113 @@
+// Copyright © 2015 Gerald Rosenberg.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the License.md file.
+//
+package generic
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestTypeRegistryBuiltinsReserved(t *testing.T) {
+	r := NewTypeRegistry()
+	id, ok := r.LookupType(reflect.TypeOf(int(0)))
+	if !ok {
+		t.Fatal("int is not preregistered")
+	}
+	if id >= firstUserID {
+		t.Errorf("builtin int TypeID = %d, want < %d", id, firstUserID)
+	}
+}
+
+func TestTypeRegistryRegisterIsStable(t *testing.T) {
+	r := NewTypeRegistry()
+	type widget struct{ N int }
+
+	id1 := r.Register(widget{})
+	id2 := r.Register(widget{})
+	if id1 != id2 {
+		t.Errorf("Register(widget) returned different ids: %d, %d", id1, id2)
+	}
+	if id1 < firstUserID {
+		t.Errorf("user type id = %d, want >= %d", id1, firstUserID)
+	}
+
+	got, ok := r.Lookup(id1)
+	if !ok || got != reflect.TypeOf(widget{}) {
+		t.Errorf("Lookup(%d) = %v, %v, want %v, true", id1, got, ok, reflect.TypeOf(widget{}))
+	}
+}
+
+func TestTypeRegistryNewOf(t *testing.T) {
+	r := NewTypeRegistry()
+	type widget struct{ N int }
+	id := r.Register(widget{})
+
+	v, err := r.NewOf(id)
+	if err != nil {
+		t.Fatalf("NewOf: unexpected error %v", err)
+	}
+	if _, ok := v.Interface().(*widget); !ok {
+		t.Errorf("NewOf(%d) = %T, want *widget", id, v.Interface())
+	}
+
+	if _, err := r.NewOf(TypeID(999999)); err == nil {
+		t.Error("NewOf(unregistered id) = nil error, want error")
+	}
+}
+
+func TestTypeRegistryRegisterName(t *testing.T) {
+	r := NewTypeRegistry()
+	type widget struct{ N int }
+
+	id := r.RegisterName("widget.v1", widget{})
+
+	got, ok := r.LookupName("widget.v1")
+	if !ok || got != id {
+		t.Errorf("LookupName(\"widget.v1\") = %v, %v, want %v, true", got, ok, id)
+	}
+
+	if _, ok := r.LookupName("no-such-name"); ok {
+		t.Error("LookupName(unregistered name) returned ok = true")
+	}
+}
+
+func TestTypeRegistryRegisterNameConflictPanics(t *testing.T) {
+	r := NewTypeRegistry()
+	type a struct{}
+	type b struct{}
+
+	r.RegisterName("dup", a{})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterName with a name already bound to a different type did not panic")
+		}
+	}()
+	r.RegisterName("dup", b{})
+}
+
+func TestTypeRegistryConcurrentRegister(t *testing.T) {
+	r := NewTypeRegistry()
+	type widget struct{ N int }
+
+	var wg sync.WaitGroup
+	ids := make([]TypeID, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ids[i] = r.Register(widget{})
+		}(i)
+	}
+	wg.Wait()
+
+	for _, id := range ids {
+		if id != ids[0] {
+			t.Errorf("concurrent Register(widget) produced differing ids: %v", ids)
+			break
+		}
+	}
+}